@@ -0,0 +1,96 @@
+// Package api exposes the monitor's recorded history over a small
+// read-only HTTP/JSON API, so an ad-hoc curl or a future dashboard can
+// query past incidents without going through the SQLite file directly.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/veron-baranige/springboot-app-monitor/internal/history"
+)
+
+// Querier is the subset of MonitorService needed to serve the API,
+// kept narrow so this package doesn't import service (which imports
+// api) and create a cycle.
+type Querier interface {
+	History(ctx context.Context, filter history.Filter) ([]history.Event, error)
+	HistoryApps(ctx context.Context) ([]string, error)
+}
+
+// NewServer builds an HTTP server exposing:
+//
+//	GET /api/events?app=&type=&limit=   recorded probes and alerts
+//	GET /api/apps                       distinct app URLs with history
+//	GET /api/alerts?app=&limit=         recorded events with outcome=alert
+//
+// It is the caller's responsibility to run it, typically via
+// ListenAndServe in its own goroutine, and to Shutdown it on exit.
+func NewServer(addr string, store Querier) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/events", handleEvents(store, ""))
+	mux.HandleFunc("/api/alerts", handleEvents(store, history.OutcomeAlert))
+	mux.HandleFunc("/api/apps", handleApps(store))
+
+	return &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+}
+
+// Shutdown gracefully stops srv, if it is non-nil.
+func Shutdown(ctx context.Context, srv *http.Server) error {
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
+}
+
+func handleEvents(store Querier, outcome history.Outcome) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		filter := history.Filter{
+			AppUrl:    query.Get("app"),
+			EventType: query.Get("type"),
+			Outcome:   outcome,
+		}
+		if limit := query.Get("limit"); limit != "" {
+			parsed, err := strconv.Atoi(limit)
+			if err != nil {
+				http.Error(w, "invalid limit", http.StatusBadRequest)
+				return
+			}
+			filter.Limit = parsed
+		}
+
+		events, err := store.History(r.Context(), filter)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, events)
+	}
+}
+
+func handleApps(store Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		apps, err := store.HistoryApps(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, apps)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
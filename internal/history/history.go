@@ -0,0 +1,56 @@
+// Package history persists every health check, metric sample and
+// dispatched alert so a future dashboard -- or an ad-hoc curl query --
+// can review incidents, compute uptime percentages, and diff current
+// vs. historical CPU/JVM baselines.
+package history
+
+import (
+	"context"
+	"time"
+)
+
+// Outcome classifies what kind of record an Event is.
+type Outcome string
+
+const (
+	// OutcomeSample is a routine health/metric probe result.
+	OutcomeSample Outcome = "sample"
+	// OutcomeAlert is a notification that was dispatched to a channel.
+	OutcomeAlert Outcome = "alert"
+)
+
+// Event is a single recorded observation or dispatched alert.
+type Event struct {
+	ID        uint `gorm:"primarykey"`
+	Timestamp time.Time
+	AppUrl    string `gorm:"index"`
+	EventType string `gorm:"index"`
+	Severity  string
+	Outcome   Outcome `gorm:"index"`
+	Message   string
+}
+
+// Filter narrows a History query. Zero-valued fields are unfiltered;
+// Limit of 0 means unlimited.
+type Filter struct {
+	AppUrl    string
+	EventType string
+	Outcome   Outcome
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+// Store persists and queries Events. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	Record(ctx context.Context, event Event) error
+	Query(ctx context.Context, filter Filter) ([]Event, error)
+	Apps(ctx context.Context) ([]string, error)
+
+	// Prune deletes every event older than before and reports how many
+	// rows were removed.
+	Prune(ctx context.Context, before time.Time) (int64, error)
+
+	Close() error
+}
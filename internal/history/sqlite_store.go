@@ -0,0 +1,98 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteStore is the default Store, backed by a local SQLite file via
+// gorm. It's a single-user desktop/server tool, so a single file-backed
+// database is simpler to operate than a client/server database.
+type sqliteStore struct {
+	db *gorm.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) a SQLite database at
+// path and migrates the Event schema.
+func NewSQLiteStore(path string) (Store, error) {
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+
+	// probeApp records a sample (and, when one fires, an alert) from its
+	// own goroutine per app, so concurrent writers are the normal case,
+	// not an edge case. SQLite only supports one writer at a time;
+	// capping the pool at a single connection serializes writes instead
+	// of letting the driver hand out concurrent connections that fail
+	// with "database is locked".
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to access history database handle: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(&Event{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate history schema: %w", err)
+	}
+
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) Record(ctx context.Context, event Event) error {
+	return s.db.WithContext(ctx).Create(&event).Error
+}
+
+func (s *sqliteStore) Query(ctx context.Context, filter Filter) ([]Event, error) {
+	query := s.db.WithContext(ctx).Model(&Event{})
+
+	if filter.AppUrl != "" {
+		query = query.Where("app_url = ?", filter.AppUrl)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("timestamp >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("timestamp <= ?", filter.Until)
+	}
+
+	query = query.Order("timestamp desc")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var events []Event
+	if err := query.Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *sqliteStore) Apps(ctx context.Context) ([]string, error) {
+	var apps []string
+	err := s.db.WithContext(ctx).Model(&Event{}).Distinct().Pluck("app_url", &apps).Error
+	return apps, err
+}
+
+func (s *sqliteStore) Prune(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("timestamp < ?", before).Delete(&Event{})
+	return result.RowsAffected, result.Error
+}
+
+func (s *sqliteStore) Close() error {
+	db, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
@@ -0,0 +1,74 @@
+// Package metrics exposes the monitor's own state in Prometheus format,
+// turning it into a scrapeable exporter so history can be graphed in
+// Grafana and alert routing can be layered on top with a real
+// Alertmanager, complementing the push-based notify subsystem.
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// HealthStatus is 1 when the app's actuator health endpoint reports
+	// UP, 0 otherwise.
+	HealthStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "springmon_health_status",
+		Help: "1 if the app's health status is UP, 0 otherwise.",
+	}, []string{"url"})
+
+	CpuUsage = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "springmon_cpu_usage",
+		Help: "Process CPU usage as a percentage of a single core.",
+	}, []string{"url"})
+
+	JvmUsedBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "springmon_jvm_used_bytes",
+		Help: "JVM heap memory currently in use, in bytes.",
+	}, []string{"url"})
+
+	JvmTotalBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "springmon_jvm_total_bytes",
+		Help: "JVM heap memory available, in bytes.",
+	}, []string{"url"})
+
+	ScrapeDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "springmon_scrape_duration_seconds",
+		Help: "Time taken to probe health and metrics for an app.",
+	}, []string{"url"})
+
+	ScrapeErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "springmon_scrape_errors_total",
+		Help: "Count of failed probes, by reason.",
+	}, []string{"url", "reason"})
+
+	AlertsSentTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "springmon_alerts_sent_total",
+		Help: "Count of alerts dispatched, by channel and severity.",
+	}, []string{"url", "channel", "severity"})
+)
+
+// NewServer builds an HTTP server exposing the metrics above on /metrics
+// at addr. It is the caller's responsibility to run it, typically via
+// ListenAndServe in its own goroutine, and to Shutdown it on exit.
+func NewServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+}
+
+// Shutdown gracefully stops srv, if it is non-nil.
+func Shutdown(ctx context.Context, srv *http.Server) error {
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
+}
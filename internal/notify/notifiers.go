@@ -0,0 +1,355 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	"gopkg.in/gomail.v2"
+)
+
+// postJSON is the shared transport for the chat-style webhook notifiers
+// below. They all boil down to "POST a small JSON body to a webhook URL".
+func postJSON(ctx context.Context, webhookUrl string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookUrl, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// discordNotifier posts to a Discord webhook.
+// URL format: discord://<webhook-id>/<webhook-token>
+type discordNotifier struct {
+	webhookUrl string
+}
+
+func newDiscordNotifier(u *url.URL) (*discordNotifier, error) {
+	webhookId := u.Host
+	token := strings.TrimPrefix(u.Path, "/")
+	if webhookId == "" || token == "" {
+		return nil, fmt.Errorf("discord: expected discord://<webhook-id>/<webhook-token>")
+	}
+	return &discordNotifier{
+		webhookUrl: fmt.Sprintf("https://discord.com/api/webhooks/%s/%s", webhookId, token),
+	}, nil
+}
+
+func (n *discordNotifier) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, n.webhookUrl, map[string]string{
+		"content": fmt.Sprintf("**%s**\n%s", event.Subject, event.Message),
+	})
+}
+
+func (n *discordNotifier) Name() string {
+	return "discord"
+}
+
+// slackNotifier posts to a Slack incoming webhook.
+// URL format: slack://<token-a>/<token-b>/<token-c>
+type slackNotifier struct {
+	webhookUrl string
+}
+
+func newSlackNotifier(u *url.URL) (*slackNotifier, error) {
+	path := strings.Trim(u.Host+u.Path, "/")
+	if path == "" {
+		return nil, fmt.Errorf("slack: expected slack://<token-a>/<token-b>/<token-c>")
+	}
+	return &slackNotifier{
+		webhookUrl: fmt.Sprintf("https://hooks.slack.com/services/%s", path),
+	}, nil
+}
+
+func (n *slackNotifier) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, n.webhookUrl, map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", event.Subject, event.Message),
+	})
+}
+
+func (n *slackNotifier) Name() string {
+	return "slack"
+}
+
+// teamsNotifier posts to a Microsoft Teams incoming webhook.
+// URL format: teams://<webhook-path>
+type teamsNotifier struct {
+	webhookUrl string
+}
+
+func newTeamsNotifier(u *url.URL) (*teamsNotifier, error) {
+	path := strings.Trim(u.Host+u.Path, "/")
+	if path == "" {
+		return nil, fmt.Errorf("teams: expected teams://<webhook-path>")
+	}
+	return &teamsNotifier{
+		webhookUrl: fmt.Sprintf("https://outlook.office.com/webhook/%s", path),
+	}, nil
+}
+
+func (n *teamsNotifier) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, n.webhookUrl, map[string]string{
+		"title": event.Subject,
+		"text":  event.Message,
+	})
+}
+
+func (n *teamsNotifier) Name() string {
+	return "teams"
+}
+
+// gotifyNotifier posts to a self-hosted Gotify server.
+// URL format: gotify://<app-token>@<host>[:port]
+type gotifyNotifier struct {
+	baseUrl string
+	token   string
+}
+
+func newGotifyNotifier(u *url.URL) (*gotifyNotifier, error) {
+	if u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("gotify: expected gotify://<app-token>@<host>")
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("gotify: missing host")
+	}
+	return &gotifyNotifier{
+		baseUrl: fmt.Sprintf("https://%s", u.Host),
+		token:   u.User.Username(),
+	}, nil
+}
+
+func (n *gotifyNotifier) Send(ctx context.Context, event Event) error {
+	endpoint := fmt.Sprintf("%s/message?token=%s", n.baseUrl, n.token)
+	return postJSON(ctx, endpoint, map[string]any{
+		"title":    event.Subject,
+		"message":  event.Message,
+		"priority": severityToGotifyPriority(event.Severity),
+	})
+}
+
+func (n *gotifyNotifier) Name() string {
+	return "gotify"
+}
+
+func severityToGotifyPriority(severity string) int {
+	switch severity {
+	case "CRITICAL":
+		return 8
+	case "WARNING":
+		return 5
+	default:
+		return 2
+	}
+}
+
+// telegramNotifier sends a message via the Telegram bot API.
+// URL format: telegram://<bot-token>@telegram?chats=<chatID1>,<chatID2>
+type telegramNotifier struct {
+	botToken string
+	chatIDs  []string
+}
+
+func newTelegramNotifier(u *url.URL) (*telegramNotifier, error) {
+	if u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("telegram: expected telegram://<bot-token>@telegram?chats=<chatID>")
+	}
+	chats := u.Query().Get("chats")
+	if chats == "" {
+		return nil, fmt.Errorf("telegram: missing chats query parameter")
+	}
+	return &telegramNotifier{
+		botToken: u.User.Username(),
+		chatIDs:  strings.Split(chats, ","),
+	}, nil
+}
+
+func (n *telegramNotifier) Send(ctx context.Context, event Event) error {
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", n.botToken)
+	text := fmt.Sprintf("%s\n%s", event.Subject, event.Message)
+
+	for _, chatID := range n.chatIDs {
+		if err := postJSON(ctx, endpoint, map[string]string{
+			"chat_id": chatID,
+			"text":    text,
+		}); err != nil {
+			return fmt.Errorf("chat %s: %w", chatID, err)
+		}
+	}
+	return nil
+}
+
+func (n *telegramNotifier) Name() string {
+	return "telegram"
+}
+
+// pushoverNotifier sends a push notification via Pushover.
+// URL format: pushover://<app-token>@<user-key>
+type pushoverNotifier struct {
+	appToken string
+	userKey  string
+}
+
+func newPushoverNotifier(u *url.URL) (*pushoverNotifier, error) {
+	if u.User == nil || u.User.Username() == "" || u.Host == "" {
+		return nil, fmt.Errorf("pushover: expected pushover://<app-token>@<user-key>")
+	}
+	return &pushoverNotifier{
+		appToken: u.User.Username(),
+		userKey:  u.Host,
+	}, nil
+}
+
+func (n *pushoverNotifier) Send(ctx context.Context, event Event) error {
+	form := url.Values{
+		"token":   {n.appToken},
+		"user":    {n.userKey},
+		"title":   {event.Subject},
+		"message": {event.Message},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.pushover.net/1/messages.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("pushover returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (n *pushoverNotifier) Name() string {
+	return "pushover"
+}
+
+// smtpNotifier sends an email over SMTP.
+// URL format: smtp://<user>:<password>@<host>:<port>/?to=a@x.com,b@x.com
+type smtpNotifier struct {
+	dialer *gomail.Dialer
+	from   string
+	to     []string
+}
+
+func newSMTPNotifier(u *url.URL) (*smtpNotifier, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("smtp: missing host")
+	}
+	to := u.Query().Get("to")
+	if to == "" {
+		return nil, fmt.Errorf("smtp: missing to query parameter")
+	}
+
+	host := u.Hostname()
+	port := 587
+	if p := u.Port(); p != "" {
+		fmt.Sscanf(p, "%d", &port)
+	}
+
+	username := ""
+	password := ""
+	if u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+	}
+
+	dialer := gomail.NewDialer(host, port, username, password)
+	dialer.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+
+	from := u.Query().Get("from")
+	if from == "" {
+		from = username
+	}
+
+	return &smtpNotifier{
+		dialer: dialer,
+		from:   from,
+		to:     strings.Split(to, ","),
+	}, nil
+}
+
+func (n *smtpNotifier) Send(ctx context.Context, event Event) error {
+	message := gomail.NewMessage()
+	message.SetHeader("From", n.from)
+	message.SetHeader("To", n.to...)
+	message.SetHeader("Subject", event.Subject)
+	message.SetBody("text/plain", event.Message)
+
+	return n.dialer.DialAndSend(message)
+}
+
+func (n *smtpNotifier) Name() string {
+	return "smtp"
+}
+
+// scriptNotifier hands the event off to a local executable, passing the
+// app URL, event type, severity and message as arguments, in that order.
+// URL format: script:///path/to/script
+type scriptNotifier struct {
+	path string
+}
+
+func newScriptNotifier(u *url.URL) (*scriptNotifier, error) {
+	if u.Path == "" {
+		return nil, fmt.Errorf("script: missing path")
+	}
+	return &scriptNotifier{path: u.Path}, nil
+}
+
+func (n *scriptNotifier) Send(ctx context.Context, event Event) error {
+	cmd := exec.CommandContext(ctx, n.path, event.AppUrl, event.Type, event.Severity, event.Message)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("script failed: %w, output: %s", err, string(output))
+	}
+	return nil
+}
+
+func (n *scriptNotifier) Name() string {
+	return "script"
+}
+
+// webhookNotifier posts a generic JSON payload to an arbitrary
+// http(s):// endpoint for destinations with no dedicated scheme.
+type webhookNotifier struct {
+	url string
+}
+
+func newWebhookNotifier(u *url.URL) (*webhookNotifier, error) {
+	return &webhookNotifier{url: u.String()}, nil
+}
+
+func (n *webhookNotifier) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, n.url, event)
+}
+
+func (n *webhookNotifier) Name() string {
+	return "webhook"
+}
@@ -0,0 +1,139 @@
+// Package notify implements a Shoutrrr-style pluggable notification
+// subsystem. Destinations are expressed as URLs (e.g. "discord://...",
+// "slack://...", "smtp://...") and parsed into concrete Notifier
+// instances at startup, so adding a new alerting channel only requires
+// adding a URL to the config, not recompiling.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Event is the payload handed to every Notifier. It is intentionally
+// generic (no dependency on the service package) so notifiers stay
+// decoupled from monitoring internals.
+type Event struct {
+	AppUrl   string
+	Type     string
+	Severity string
+	Subject  string
+	Message  string
+}
+
+// Notifier delivers an Event to a single destination.
+type Notifier interface {
+	Send(ctx context.Context, event Event) error
+
+	// Name identifies the channel kind (e.g. "discord", "smtp") for
+	// logging and metrics labels.
+	Name() string
+}
+
+// Registry holds the set of notifiers parsed from configured URLs and
+// fans events out to all of them.
+type Registry struct {
+	notifiers []Notifier
+}
+
+// NewRegistry parses urls into concrete notifiers. A url that fails to
+// parse is reported immediately rather than silently dropped, since a
+// typo'd destination should fail startup, not alerting at 3am.
+func NewRegistry(urls []string) (*Registry, error) {
+	notifiers := make([]Notifier, 0, len(urls))
+	for _, raw := range urls {
+		n, err := Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("notify: failed to parse url %q: %w", raw, err)
+		}
+		notifiers = append(notifiers, n)
+	}
+	return &Registry{notifiers: notifiers}, nil
+}
+
+// Parse turns a single destination URL into a Notifier based on its
+// scheme.
+func Parse(raw string) (Notifier, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "discord":
+		return newDiscordNotifier(u)
+	case "slack":
+		return newSlackNotifier(u)
+	case "telegram":
+		return newTelegramNotifier(u)
+	case "pushover":
+		return newPushoverNotifier(u)
+	case "smtp":
+		return newSMTPNotifier(u)
+	case "teams":
+		return newTeamsNotifier(u)
+	case "gotify":
+		return newGotifyNotifier(u)
+	case "script":
+		return newScriptNotifier(u)
+	case "http", "https":
+		return newWebhookNotifier(u)
+	default:
+		return nil, fmt.Errorf("unsupported notify scheme: %q", u.Scheme)
+	}
+}
+
+// DispatchResult reports the outcome of sending an Event to a single
+// named channel.
+type DispatchResult struct {
+	Channel string
+	Err     error
+}
+
+// Dispatch sends event to every registered notifier in parallel and
+// returns one DispatchResult per notifier. A failing channel never
+// prevents delivery on the others.
+func (r *Registry) Dispatch(ctx context.Context, event Event) []DispatchResult {
+	var g errgroup.Group
+	var mu sync.Mutex
+	results := make([]DispatchResult, 0, len(r.notifiers))
+
+	for _, n := range r.notifiers {
+		n := n
+		g.Go(func() error {
+			err := n.Send(ctx, event)
+			if err != nil {
+				err = fmt.Errorf("%s: %w", n.Name(), err)
+			}
+
+			mu.Lock()
+			results = append(results, DispatchResult{Channel: n.Name(), Err: err})
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	g.Wait()
+	return results
+}
+
+// Errors joins every failing result's error, or nil if all succeeded.
+func Errors(results []DispatchResult) error {
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Len reports how many notifiers are registered.
+func (r *Registry) Len() int {
+	return len(r.notifiers)
+}
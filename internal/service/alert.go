@@ -0,0 +1,169 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// Severity classifies how urgently an event needs a human's attention,
+// mirroring the INFO/WARNING/CRITICAL routing levels used by tools like
+// Prometheus Alertmanager.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "INFO"
+	SeverityWarning  Severity = "WARNING"
+	SeverityCritical Severity = "CRITICAL"
+)
+
+// rank orders severities so they can be compared; higher is more urgent.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// atLeast reports whether s is at least as urgent as min.
+func (s Severity) atLeast(min Severity) bool {
+	return s.rank() >= min.rank()
+}
+
+// EventType identifies the kind of condition an alert was raised for.
+// It doubles as the dedup key alongside the app URL, and as the lookup
+// key into MessageTemplates.
+type EventType string
+
+const (
+	EventTypeNoActuatorSupport EventType = "no_actuator_support"
+	EventTypeNotResponding     EventType = "not_responding"
+	EventTypeHealthStatus      EventType = "health_status"
+	EventTypeHealthStatusError EventType = "health_status_error"
+	EventTypeMetricsError      EventType = "metrics_error"
+	EventTypeThresholdExceeded EventType = "threshold_exceeded"
+	EventTypeHealthy           EventType = "healthy"
+	EventTypeResolved          EventType = "resolved"
+)
+
+// AppConfig carries the per-app overrides for an individual monitored
+// Spring Boot app. Zero-valued fields fall back to the MonitorConfig
+// defaults in NewMonitorService.
+type AppConfig struct {
+	BaseUrl      string
+	CpuThreshold uint32
+	JvmThreshold uint32
+	MinSeverity  Severity
+
+	// Schedule is a robfig/cron expression (e.g. "@every 30s") this app
+	// is probed on. Empty falls back to MonitorConfig.DefaultSchedule.
+	Schedule string
+}
+
+// alertKey identifies a specific recurring condition for an app, e.g.
+// "cpu threshold exceeded on https://foo". It is the dedup unit.
+type alertKey struct {
+	appUrl    string
+	eventType EventType
+}
+
+type alertState struct {
+	firing         bool
+	lastNotifiedAt time.Time
+	severity       Severity
+}
+
+// resolvedCondition is a condition that just transitioned from firing to
+// cleared, along with the severity it was firing at. Resolved
+// notifications are sent at this severity (rather than a fixed INFO) so
+// they pass the same per-app MinSeverity filter the original alert did.
+type resolvedCondition struct {
+	eventType EventType
+	severity  Severity
+}
+
+// alertCache suppresses repeat notifications for a condition that is
+// still firing, and surfaces a "resolved" transition exactly once when
+// the condition clears.
+type alertCache struct {
+	mu     sync.Mutex
+	states map[alertKey]*alertState
+}
+
+func newAlertCache() *alertCache {
+	return &alertCache{states: make(map[alertKey]*alertState)}
+}
+
+// evaluate records the current firing/cleared state of key and reports
+// whether a notification should go out for it right now, and whether
+// this call represents a resolved transition (condition cleared after
+// having fired).
+//
+// While firing, repeat notifications are suppressed unless
+// renotifyInterval has elapsed since the last one (0 disables re-notify
+// entirely). The first time a condition clears after having fired, a
+// resolved notification is always sent regardless of renotifyInterval,
+// at the severity the condition was firing at.
+func (c *alertCache) evaluate(key alertKey, firing bool, severity Severity, renotifyInterval time.Duration, now time.Time) (shouldNotify bool, resolved bool, resolvedSeverity Severity) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, ok := c.states[key]
+	if !ok {
+		state = &alertState{}
+		c.states[key] = state
+	}
+
+	if firing {
+		state.severity = severity
+
+		if !state.firing {
+			state.firing = true
+			state.lastNotifiedAt = now
+			return true, false, ""
+		}
+
+		if renotifyInterval > 0 && now.Sub(state.lastNotifiedAt) >= renotifyInterval {
+			state.lastNotifiedAt = now
+			return true, false, ""
+		}
+
+		return false, false, ""
+	}
+
+	if state.firing {
+		state.firing = false
+		state.lastNotifiedAt = now
+		return true, true, state.severity
+	}
+
+	return false, false, ""
+}
+
+// resolveOthers clears every other still-firing condition tracked for
+// appUrl besides except, returning the conditions that transitioned to
+// resolved along with the severity each was firing at.
+// probeApp only ever detects one condition per app per
+// tick, so whenever a different condition is observed (or the app turns
+// out healthy) any previously firing condition of another type is, by
+// construction, no longer happening.
+func (c *alertCache) resolveOthers(appUrl string, except EventType, now time.Time) []resolvedCondition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var resolved []resolvedCondition
+	for key, state := range c.states {
+		if key.appUrl != appUrl || key.eventType == except {
+			continue
+		}
+		if state.firing {
+			state.firing = false
+			state.lastNotifiedAt = now
+			resolved = append(resolved, resolvedCondition{eventType: key.eventType, severity: state.severity})
+		}
+	}
+	return resolved
+}
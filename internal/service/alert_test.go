@@ -0,0 +1,85 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlertCacheEvaluate(t *testing.T) {
+	key := alertKey{appUrl: "https://app.example.com", eventType: EventTypeThresholdExceeded}
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("first firing notifies and is not resolved", func(t *testing.T) {
+		c := newAlertCache()
+
+		shouldNotify, resolved, _ := c.evaluate(key, true, SeverityWarning, 0, now)
+		if !shouldNotify || resolved {
+			t.Fatalf("evaluate() = (%v, %v), want (true, false)", shouldNotify, resolved)
+		}
+	})
+
+	t.Run("repeat firing is suppressed without a re-notify interval", func(t *testing.T) {
+		c := newAlertCache()
+		c.evaluate(key, true, SeverityWarning, 0, now)
+
+		shouldNotify, _, _ := c.evaluate(key, true, SeverityWarning, 0, now.Add(time.Minute))
+		if shouldNotify {
+			t.Fatalf("evaluate() shouldNotify = true, want false while still firing with no re-notify interval")
+		}
+	})
+
+	t.Run("repeat firing re-notifies once the interval elapses", func(t *testing.T) {
+		c := newAlertCache()
+		c.evaluate(key, true, SeverityWarning, 10*time.Minute, now)
+
+		shouldNotify, resolved, _ := c.evaluate(key, true, SeverityWarning, 10*time.Minute, now.Add(11*time.Minute))
+		if !shouldNotify || resolved {
+			t.Fatalf("evaluate() = (%v, %v), want (true, false) after the re-notify interval elapsed", shouldNotify, resolved)
+		}
+	})
+
+	t.Run("clearing after firing reports resolved at the firing severity", func(t *testing.T) {
+		c := newAlertCache()
+		c.evaluate(key, true, SeverityCritical, 0, now)
+
+		shouldNotify, resolved, resolvedSeverity := c.evaluate(key, false, "", 0, now.Add(time.Minute))
+		if !shouldNotify || !resolved || resolvedSeverity != SeverityCritical {
+			t.Fatalf("evaluate() = (%v, %v, %v), want (true, true, %v)", shouldNotify, resolved, resolvedSeverity, SeverityCritical)
+		}
+	})
+
+	t.Run("clearing a condition that never fired does nothing", func(t *testing.T) {
+		c := newAlertCache()
+
+		shouldNotify, resolved, _ := c.evaluate(key, false, "", 0, now)
+		if shouldNotify || resolved {
+			t.Fatalf("evaluate() = (%v, %v), want (false, false)", shouldNotify, resolved)
+		}
+	})
+}
+
+func TestAlertCacheResolveOthers(t *testing.T) {
+	c := newAlertCache()
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	appUrl := "https://app.example.com"
+
+	c.evaluate(alertKey{appUrl: appUrl, eventType: EventTypeThresholdExceeded}, true, SeverityWarning, 0, now)
+	c.evaluate(alertKey{appUrl: appUrl, eventType: EventTypeNotResponding}, true, SeverityCritical, 0, now)
+	c.evaluate(alertKey{appUrl: "https://other.example.com", eventType: EventTypeThresholdExceeded}, true, SeverityWarning, 0, now)
+
+	resolved := c.resolveOthers(appUrl, EventTypeNotResponding, now.Add(time.Minute))
+
+	if len(resolved) != 1 {
+		t.Fatalf("resolveOthers() returned %d conditions, want 1: %+v", len(resolved), resolved)
+	}
+	if resolved[0].eventType != EventTypeThresholdExceeded || resolved[0].severity != SeverityWarning {
+		t.Errorf("resolveOthers() = %+v, want {%v %v}", resolved[0], EventTypeThresholdExceeded, SeverityWarning)
+	}
+
+	// The other app's condition must be unaffected by resolveOthers: it
+	// should still report a resolved transition when it actually clears.
+	_, resolved2, severity2 := c.evaluate(alertKey{appUrl: "https://other.example.com", eventType: EventTypeThresholdExceeded}, false, "", 0, now.Add(time.Minute))
+	if !resolved2 || severity2 != SeverityWarning {
+		t.Errorf("other app's condition should be unaffected by resolveOthers, got resolved=%v severity=%v", resolved2, severity2)
+	}
+}
@@ -8,135 +8,543 @@ import (
 	"log"
 	"net/http"
 	"os/exec"
+	"sync"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	"github.com/spf13/viper"
+	"github.com/veron-baranige/springboot-app-monitor/internal/api"
+	"github.com/veron-baranige/springboot-app-monitor/internal/history"
+	"github.com/veron-baranige/springboot-app-monitor/internal/metrics"
 	"github.com/veron-baranige/springboot-app-monitor/internal/monitor"
+	"github.com/veron-baranige/springboot-app-monitor/internal/notify"
 	"gopkg.in/gomail.v2"
 )
 
+// defaultHistoryRetention is used when HistoryRetention is unset but a
+// HistoryDBPath is configured.
+const defaultHistoryRetention = 30 * 24 * time.Hour
+
+// historyPruneInterval is how often the retention pruner checks for
+// expired history records.
+const historyPruneInterval = 1 * time.Hour
+
+// defaultSchedule is used for any app that doesn't set Schedule or
+// DefaultSchedule.
+const defaultSchedule = "@every 30s"
+
+// bytesPerGB converts the GB-denominated JVM memory figures returned by
+// monitor.GetMetrics into bytes for the Prometheus gauges.
+const bytesPerGB = 1e9
+
 type MonitorConfig struct {
-	AppLogoPath           string
-	TestConnectivityUrl   string
-	AlertSoundPath        string
+	AppLogoPath         string
+	TestConnectivityUrl string
+	AlertSoundPath      string
+
+	// Apps is the set of monitored Spring Boot apps along with their
+	// per-app overrides. A missing CpuThreshold, JvmThreshold or
+	// MinSeverity is filled in from the Default* fields below.
+	Apps                []AppConfig
+	DefaultCpuThreshold uint32
+	DefaultJvmThreshold uint32
+	DefaultMinSeverity  Severity
 
-	UrlsToMonitor         []string
-	MonitorInterval       time.Duration
-	CpuUsageWarnThreshold uint32
-	JvmUsageWarnThreshold uint32
+	// DefaultSchedule is the robfig/cron expression used for any app
+	// that doesn't set its own AppConfig.Schedule. Defaults to
+	// "@every 30s".
+	DefaultSchedule string
+
+	// QuietHours are recurring windows during which alerts below each
+	// window's MinSeverityDuringQuiet are suppressed from notification
+	// channels, though still recorded to metrics/logs.
+	QuietHours []TimeWindow
+
+	// ReNotifyInterval controls how often a still-firing condition is
+	// re-notified. 0 disables re-notification entirely; the alert only
+	// fires once until it resolves.
+	ReNotifyInterval time.Duration
+
+	// NotifyUrls are Shoutrrr-style destination URLs (e.g. "discord://",
+	// "slack://", "smtp://") parsed into notify.Notifiers at startup.
+	// Alongside the legacy desktop/email options below, this lets users
+	// pick their alerting channel without code changes.
+	NotifyUrls []string
 
 	MailDialer       *gomail.Dialer
 	EmailReceipients []string
 
 	IsDesktopAlertsEnabled bool
 	IsEmailAlertsEnabled   bool
+
+	// MetricsAddr, when non-empty, is the address (e.g. ":9090") the
+	// Prometheus /metrics endpoint is served on.
+	MetricsAddr string
+
+	// MessageTemplates overrides the text/template used to render a
+	// given EventType's alert body; any EventType not present here
+	// keeps its default wording. SubjectTemplate overrides the subject
+	// line, rendered with the same TemplateContext. Both are
+	// precompiled once in NewMonitorService.
+	MessageTemplates map[EventType]string
+	SubjectTemplate  string
+
+	// HistoryDBPath, when non-empty, enables the history subsystem: a
+	// SQLite file at this path records every probe and dispatched
+	// alert so History (and the /api/* HTTP API) can serve past
+	// incidents. HistoryRetention bounds how long records are kept;
+	// it defaults to 30 days and is enforced by a background pruner.
+	HistoryDBPath    string
+	HistoryRetention time.Duration
+
+	// HistoryApiAddr, when non-empty, serves the read-only /api/events,
+	// /api/apps and /api/alerts JSON endpoints on this address (e.g.
+	// ":8081"). Requires HistoryDBPath to be set.
+	HistoryApiAddr string
 }
 
 type MonitorService struct {
-	config MonitorConfig
+	config        MonitorConfig
+	notifier      *notify.Registry
+	alerts        *alertCache
+	renderer      *messageRenderer
+	metricsServer *http.Server
+	apiServer     *http.Server
+
+	cron       *cron.Cron
+	guard      *probeGuard
+	wg         sync.WaitGroup
+	history    history.Store
+	historyWg  sync.WaitGroup
+	notifyWg   sync.WaitGroup
+	prunerStop chan struct{}
+}
+
+func NewMonitorService(config MonitorConfig) (*MonitorService, error) {
+	notifier, err := notify.NewRegistry(config.NotifyUrls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up notify destinations: %w", err)
+	}
+
+	renderer, err := newMessageRenderer(config.SubjectTemplate, config.MessageTemplates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile message templates: %w", err)
+	}
+
+	if config.DefaultMinSeverity == "" {
+		config.DefaultMinSeverity = SeverityInfo
+	}
+	if config.DefaultSchedule == "" {
+		config.DefaultSchedule = defaultSchedule
+	}
+	if config.HistoryDBPath != "" && config.HistoryRetention == 0 {
+		config.HistoryRetention = defaultHistoryRetention
+	}
+
+	for i := range config.Apps {
+		app := &config.Apps[i]
+		if app.CpuThreshold == 0 {
+			app.CpuThreshold = config.DefaultCpuThreshold
+		}
+		if app.JvmThreshold == 0 {
+			app.JvmThreshold = config.DefaultJvmThreshold
+		}
+		if app.MinSeverity == "" {
+			app.MinSeverity = config.DefaultMinSeverity
+		}
+		if app.Schedule == "" {
+			app.Schedule = config.DefaultSchedule
+		}
+	}
+
+	ms := &MonitorService{
+		config:   config,
+		notifier: notifier,
+		alerts:   newAlertCache(),
+		renderer: renderer,
+		guard:    newProbeGuard(),
+	}
+
+	if config.MetricsAddr != "" {
+		ms.metricsServer = metrics.NewServer(config.MetricsAddr)
+	}
+
+	if config.HistoryDBPath != "" {
+		store, err := history.NewSQLiteStore(config.HistoryDBPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open history store: %w", err)
+		}
+		ms.history = store
+		ms.prunerStop = make(chan struct{})
+
+		if config.HistoryApiAddr != "" {
+			ms.apiServer = api.NewServer(config.HistoryApiAddr, ms)
+		}
+	} else if config.HistoryApiAddr != "" {
+		log.Printf("HistoryApiAddr is set but HistoryDBPath is empty; history api will not be served")
+	}
+
+	return ms, nil
+}
+
+// History returns past events matching filter, most recent first. It
+// returns an error if no HistoryDBPath was configured.
+func (ms *MonitorService) History(ctx context.Context, filter history.Filter) ([]history.Event, error) {
+	if ms.history == nil {
+		return nil, fmt.Errorf("history is not enabled: set MonitorConfig.HistoryDBPath")
+	}
+	return ms.history.Query(ctx, filter)
 }
 
-func NewMonitorService(config MonitorConfig) *MonitorService {
-	return &MonitorService{
-		config: config,
+// HistoryApps returns the distinct app URLs with recorded history.
+func (ms *MonitorService) HistoryApps(ctx context.Context) ([]string, error) {
+	if ms.history == nil {
+		return nil, fmt.Errorf("history is not enabled: set MonitorConfig.HistoryDBPath")
+	}
+	return ms.history.Apps(ctx)
+}
+
+// recordHistory persists event to the history store, if enabled. It
+// never blocks monitoring on a slow disk: failures are logged, not
+// propagated.
+func (ms *MonitorService) recordHistory(event history.Event) {
+	if ms.history == nil {
+		return
 	}
+
+	ms.historyWg.Add(1)
+	go func() {
+		defer ms.historyWg.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := ms.history.Record(ctx, event); err != nil {
+			log.Printf("failed to record history event: %v", err)
+		}
+	}()
 }
 
+// Start schedules every configured app on its own cron expression and
+// returns once scheduling is set up; it does not block, as probes run on
+// the cron scheduler's own goroutine. Call Stop to shut down cleanly.
 func (ms *MonitorService) Start() {
 	log.Println("started monitoring service")
 
-	ms.monitorHealthAndMetrics()
+	if ms.metricsServer != nil {
+		go func() {
+			log.Printf("serving metrics on %s/metrics", ms.metricsServer.Addr)
+			if err := ms.metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("metrics server stopped: %v", err)
+			}
+		}()
+	}
+
+	if ms.apiServer != nil {
+		go func() {
+			log.Printf("serving history api on %s/api", ms.apiServer.Addr)
+			if err := ms.apiServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("history api server stopped: %v", err)
+			}
+		}()
+	}
+
+	if ms.history != nil {
+		go ms.pruneHistoryLoop()
+	}
+
+	ms.cron = cron.New()
+	for _, app := range ms.config.Apps {
+		app := app
+		if _, err := ms.cron.AddFunc(app.Schedule, func() { ms.probeApp(app) }); err != nil {
+			log.Printf("invalid schedule %q for %s: %v", app.Schedule, app.BaseUrl, err)
+		}
+	}
+	ms.cron.Start()
+}
+
+// Stop stops scheduling new probes and waits, up to ctx's deadline, for
+// any probes already in flight, and any notifications they fired, to
+// finish.
+func (ms *MonitorService) Stop(ctx context.Context) error {
+	if ms.cron != nil {
+		cronStopped := ms.cron.Stop()
+		select {
+		case <-cronStopped.Done():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		ms.wg.Wait()
+		ms.notifyWg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if ms.prunerStop != nil {
+		close(ms.prunerStop)
+	}
+
+	apiErr := api.Shutdown(ctx, ms.apiServer)
+	metricsErr := metrics.Shutdown(ctx, ms.metricsServer)
+
+	// Wait for in-flight history writes before closing the store, and
+	// close it regardless of the errors above so we don't leak the
+	// underlying file handle on a slow/failed HTTP shutdown.
+	ms.historyWg.Wait()
+	var historyErr error
+	if ms.history != nil {
+		historyErr = ms.history.Close()
+	}
+
+	return errors.Join(apiErr, metricsErr, historyErr)
+}
 
-	ticker := time.NewTicker(ms.config.MonitorInterval)
+// pruneHistoryLoop periodically deletes history records older than
+// ms.config.HistoryRetention until prunerStop is closed.
+func (ms *MonitorService) pruneHistoryLoop() {
+	ticker := time.NewTicker(historyPruneInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		ms.monitorHealthAndMetrics()
+	for {
+		select {
+		case <-ms.prunerStop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			deleted, err := ms.history.Prune(ctx, time.Now().Add(-ms.config.HistoryRetention))
+			cancel()
+
+			if err != nil {
+				log.Printf("failed to prune history: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("pruned %d history record(s) older than %s", deleted, ms.config.HistoryRetention)
+			}
+		}
 	}
 }
 
-func (ms *MonitorService) monitorHealthAndMetrics() {
+// probeApp checks health/metrics for a single app. It is invoked by the
+// cron scheduler on app.Schedule; probeGuard ensures a slow probe never
+// overlaps with the next scheduled tick for the same app.
+func (ms *MonitorService) probeApp(app AppConfig) {
+	baseUrl := app.BaseUrl
+
+	if !ms.guard.tryStart(baseUrl) {
+		log.Printf("skipping tick for %s: previous probe still in flight", baseUrl)
+		return
+	}
+	defer ms.guard.finish(baseUrl)
+
+	ms.wg.Add(1)
+	defer ms.wg.Done()
+
 	if !hasInternetConnection(ms.config.TestConnectivityUrl) {
 		log.Println("No internect connection available for monitoring. Skipping monitoring for now.")
-		// msg := fmt.Sprintf("[%s] NO INTERNET CONNECTIVITY", time.Now().Format("15:04"))
-		// notify.Notify("Spring Boot App Monitor", "Spring Boot App Monitor", msg, ms.config.AppLogoPath)
 		return
 	}
 
-	for i, baseUrl := range ms.config.UrlsToMonitor {
-		go func(baseUrl string) {
-			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
-			defer cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
 
-			status, err := monitor.GetHealthStatus(ctx, baseUrl)
-			if err != nil {
-				if errors.Is(err, monitor.ErrNoActuatorSupport) {
-					log.Printf("No actuator support for: %s", baseUrl)
-					msg := fmt.Sprintf("[%s] No actuator support for: %s", 
-						time.Now().Format("15:04"), baseUrl+"/actuator")
-					ms.handleAlert(baseUrl, msg, true, true)
-					return
-				}
+	scrapeStart := time.Now()
+	defer func() {
+		metrics.ScrapeDuration.WithLabelValues(baseUrl).Observe(time.Since(scrapeStart).Seconds())
+	}()
 
-				if errors.Is(err, monitor.ErrNotResponding) {
-					log.Printf("Timeout exceeded. No response from: %s", baseUrl)
-					msg := fmt.Sprintf("[%s] %s", 
-						time.Now().Format("15:04"), "No response from app. Attention required!")
-					ms.handleAlert(baseUrl, msg, true, true)
-					return
-				}
+	status, err := monitor.GetHealthStatus(ctx, baseUrl)
+	if err != nil {
+		metrics.HealthStatus.WithLabelValues(baseUrl).Set(0)
 
-				log.Printf("Failed to get health status. Err: %s", err)
-				msg := fmt.Sprintf("[%s] Failed to get health status: %s", time.Now().Format("15:04"), err)
-				ms.handleAlert(baseUrl, msg, true, true)
-				return
-			}
+		if errors.Is(err, monitor.ErrNoActuatorSupport) {
+			log.Printf("No actuator support for: %s", baseUrl)
+			metrics.ScrapeErrorsTotal.WithLabelValues(baseUrl, "no_actuator_support").Inc()
+			ms.raiseEvent(app, EventTypeNoActuatorSupport, SeverityCritical, TemplateContext{})
+			return
+		}
 
-			if status != monitor.Up {
-				log.Printf("Health status: %s for: %s", status, baseUrl)
-				msg := fmt.Sprintf("[%s] Health Status: %s. Attention required!", 
-					time.Now().Format("15:04"), string(status))
-				ms.handleAlert(baseUrl, msg, true, true)
-				return
-			}
+		if errors.Is(err, monitor.ErrNotResponding) {
+			log.Printf("Timeout exceeded. No response from: %s", baseUrl)
+			metrics.ScrapeErrorsTotal.WithLabelValues(baseUrl, "not_responding").Inc()
+			ms.raiseEvent(app, EventTypeNotResponding, SeverityCritical, TemplateContext{})
+			return
+		}
 
-			metrics, err := monitor.GetMetrics(ctx, baseUrl)
-			if err != nil {
-				log.Printf("Failed to get metrics for: %s. Err: %s", baseUrl, err)
-				msg := fmt.Sprintf("[%s] Health status: %s. Failed to get metrics: %s", 
-					time.Now().Format("15:04"), string(status), err)
-				ms.handleAlert(baseUrl, msg, false, false)
-				return
-			}
+		log.Printf("Failed to get health status. Err: %s", err)
+		metrics.ScrapeErrorsTotal.WithLabelValues(baseUrl, "health_status_error").Inc()
+		ms.raiseEvent(app, EventTypeHealthStatusError, SeverityCritical, TemplateContext{Error: err.Error()})
+		return
+	}
+
+	if status != monitor.Up {
+		log.Printf("Health status: %s for: %s", status, baseUrl)
+		metrics.HealthStatus.WithLabelValues(baseUrl).Set(0)
+		ms.raiseEvent(app, EventTypeHealthStatus, SeverityCritical, TemplateContext{Status: string(status)})
+		return
+	}
+
+	metrics.HealthStatus.WithLabelValues(baseUrl).Set(1)
 
-			exceededCpuUsageThreshold := metrics.CpuUsage * metrics.CpuCount > float64(ms.config.CpuUsageWarnThreshold)
-			exceededJvmUsageThreshold := metrics.MemoryTotal > 0.0 && 
-				(metrics.MemoryUsed / metrics.MemoryTotal)*100 > float64(ms.config.JvmUsageWarnThreshold)
-
-			if exceededCpuUsageThreshold || exceededJvmUsageThreshold {
-				log.Printf("Exceeded CPU/JVM threshold: %s", baseUrl)
-                msg := fmt.Sprintf("[%s] Attention required! CPU: %.2f%%, JVM: %.1f/%.1f GB", 
-					time.Now().Format("15:04"), metrics.CpuUsage*metrics.CpuCount, metrics.MemoryUsed, metrics.MemoryTotal)
-                ms.handleAlert(baseUrl, msg, true, true)
-                return
-            }
-			    
-			log.Printf("[%v] CPU: %.2f%%, JVM: %.1f/%.1f GB", 
-				baseUrl, metrics.CpuUsage*metrics.CpuCount, metrics.MemoryUsed, metrics.MemoryTotal)
-			msg := fmt.Sprintf("[%v] CPU: %.2f%%, JVM: %.1f/%.1f GB",
-				time.Now().Format("15:04"), metrics.CpuUsage*metrics.CpuCount, metrics.MemoryUsed, metrics.MemoryTotal)
-			ms.handleAlert(baseUrl, msg, false, false)
-		}(baseUrl)
-
-		// wait before monitoring next app to provide notification read time
-		if len(ms.config.UrlsToMonitor) > 1 && i == len(ms.config.UrlsToMonitor)-1 {
-			time.Sleep(6 * time.Second)
+	appMetrics, err := monitor.GetMetrics(ctx, baseUrl)
+	if err != nil {
+		log.Printf("Failed to get metrics for: %s. Err: %s", baseUrl, err)
+		metrics.ScrapeErrorsTotal.WithLabelValues(baseUrl, "metrics_error").Inc()
+		ms.raiseEvent(app, EventTypeMetricsError, SeverityWarning, TemplateContext{
+			Status: string(status),
+			Error:  err.Error(),
+		})
+		return
+	}
+
+	metrics.CpuUsage.WithLabelValues(baseUrl).Set(appMetrics.CpuUsage * appMetrics.CpuCount)
+	metrics.JvmUsedBytes.WithLabelValues(baseUrl).Set(appMetrics.MemoryUsed * bytesPerGB)
+	metrics.JvmTotalBytes.WithLabelValues(baseUrl).Set(appMetrics.MemoryTotal * bytesPerGB)
+
+	metricsCtx := TemplateContext{
+		CpuUsage:    appMetrics.CpuUsage * appMetrics.CpuCount,
+		CpuCount:    appMetrics.CpuCount,
+		MemoryUsed:  appMetrics.MemoryUsed,
+		MemoryTotal: appMetrics.MemoryTotal,
+	}
+
+	exceededCpuUsageThreshold := appMetrics.CpuUsage*appMetrics.CpuCount > float64(app.CpuThreshold)
+	exceededJvmUsageThreshold := appMetrics.MemoryTotal > 0.0 &&
+		(appMetrics.MemoryUsed/appMetrics.MemoryTotal)*100 > float64(app.JvmThreshold)
+
+	if exceededCpuUsageThreshold || exceededJvmUsageThreshold {
+		log.Printf("Exceeded CPU/JVM threshold: %s", baseUrl)
+		threshold := app.CpuThreshold
+		if exceededJvmUsageThreshold {
+			threshold = app.JvmThreshold
 		}
+		metricsCtx.Threshold = float64(threshold)
+		ms.raiseEvent(app, EventTypeThresholdExceeded, SeverityWarning, metricsCtx)
+		return
+	}
+
+	log.Printf("[%v] CPU: %.2f%%, JVM: %.1f/%.1f GB",
+		baseUrl, appMetrics.CpuUsage*appMetrics.CpuCount, appMetrics.MemoryUsed, appMetrics.MemoryTotal)
+	ms.raiseEvent(app, EventTypeHealthy, SeverityInfo, metricsCtx)
+}
+
+// raiseEvent classifies a single observation for app, applying
+// dedup/re-notify suppression and emitting a resolved event for any
+// other condition that was firing for this app. At most one condition
+// is ever observed per app per tick, so seeing eventType implies every
+// other tracked condition for this app is no longer happening.
+func (ms *MonitorService) raiseEvent(app AppConfig, eventType EventType, severity Severity, ctx TemplateContext) {
+	now := time.Now()
+
+	ms.recordHistory(history.Event{
+		Timestamp: now,
+		AppUrl:    app.BaseUrl,
+		EventType: string(eventType),
+		Severity:  string(severity),
+		Outcome:   history.OutcomeSample,
+		Message: fmt.Sprintf("status=%s error=%s cpu=%.2f jvmUsed=%.1f jvmTotal=%.1f",
+			ctx.Status, ctx.Error, ctx.CpuUsage, ctx.MemoryUsed, ctx.MemoryTotal),
+	})
+
+	for _, resolvedCond := range ms.alerts.resolveOthers(app.BaseUrl, eventType, now) {
+		ms.handleAlert(app, EventTypeResolved, resolvedCond.severity, TemplateContext{Status: string(resolvedCond.eventType)})
+	}
+
+	firing := eventType != EventTypeHealthy
+	shouldNotify, resolved, resolvedSeverity := ms.alerts.evaluate(alertKey{appUrl: app.BaseUrl, eventType: eventType}, firing, severity, ms.config.ReNotifyInterval, now)
+	if !shouldNotify {
+		return
+	}
+
+	if resolved {
+		ms.handleAlert(app, EventTypeResolved, resolvedSeverity, TemplateContext{})
+		return
 	}
+
+	ms.handleAlert(app, eventType, severity, ctx)
 }
 
-func (ms *MonitorService) handleAlert(appBaseUrl string, msgContent string, isAlert bool, sendMail bool) {
+func (ms *MonitorService) handleAlert(app AppConfig, eventType EventType, severity Severity, ctx TemplateContext) {
+	minSeverity := app.MinSeverity
+	if quietFloor, inQuietHours := quietSeverityFloor(ms.config.QuietHours, time.Now()); inQuietHours && quietFloor.rank() > minSeverity.rank() {
+		minSeverity = quietFloor
+	}
+
+	if !severity.atLeast(minSeverity) {
+		return
+	}
+
+	appBaseUrl := app.BaseUrl
+	isAlert := severity == SeverityCritical
+	sendMail := severity.atLeast(SeverityWarning)
+
+	ctx.AppUrl = appBaseUrl
+	ctx.Severity = severity
+	if ctx.Timestamp.IsZero() {
+		ctx.Timestamp = time.Now()
+	}
+
+	msgContent, err := ms.renderer.renderMessage(eventType, ctx)
+	if err != nil {
+		log.Printf("failed to render message template for %q: %v", eventType, err)
+		msgContent = fmt.Sprintf("[%s] %s event for %s", ctx.Timestamp.Format("15:04"), eventType, appBaseUrl)
+	}
+
+	ms.recordHistory(history.Event{
+		Timestamp: ctx.Timestamp,
+		AppUrl:    appBaseUrl,
+		EventType: string(eventType),
+		Severity:  string(severity),
+		Outcome:   history.OutcomeAlert,
+		Message:   msgContent,
+	})
+
+	subject, err := ms.renderer.renderSubject(ctx)
+	if err != nil {
+		log.Printf("failed to render subject template: %v", err)
+		subject = "Spring Boot App Monitor - " + appBaseUrl
+	}
+
+	if ms.notifier.Len() > 0 {
+		event := notify.Event{
+			AppUrl:   appBaseUrl,
+			Type:     string(eventType),
+			Severity: string(severity),
+			Subject:  subject,
+			Message:  msgContent,
+		}
+
+		// Dispatched in its own goroutine so a slow or failing channel
+		// never blocks the monitoring loop for this app. Tracked on
+		// notifyWg so Stop can drain it before the process exits.
+		ms.notifyWg.Add(1)
+		go func() {
+			defer ms.notifyWg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			for _, result := range ms.notifier.Dispatch(ctx, event) {
+				metrics.AlertsSentTotal.WithLabelValues(appBaseUrl, result.Channel, string(severity)).Inc()
+				if result.Err != nil {
+					log.Printf("failed to dispatch notification: %v", result.Err)
+				}
+			}
+		}()
+	}
+
 	if ms.config.IsDesktopAlertsEnabled {
+		metrics.AlertsSentTotal.WithLabelValues(appBaseUrl, "desktop", string(severity)).Inc()
+
 		if !isAlert {
 			if output, err := exec.Command("notify-send", "-u", "normal", "-i", ms.config.AppLogoPath, appBaseUrl, msgContent).CombinedOutput(); err != nil {
 				log.Printf("failed to send desktop notification: err: %v, output: %v", err, string(output))
@@ -151,7 +559,9 @@ func (ms *MonitorService) handleAlert(appBaseUrl string, msgContent string, isAl
 	}
 
 	if ms.config.IsEmailAlertsEnabled && sendMail {
-		mailErr := sendEmail(ms.config.MailDialer, ms.config.EmailReceipients, "Spring Boot App Monitor - " + appBaseUrl, msgContent)
+		metrics.AlertsSentTotal.WithLabelValues(appBaseUrl, "email", string(severity)).Inc()
+
+		mailErr := sendEmail(ms.config.MailDialer, ms.config.EmailReceipients, subject, msgContent)
 		if mailErr != nil {
 			log.Println("Failed to send email: ", mailErr)
 		}
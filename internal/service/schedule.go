@@ -0,0 +1,125 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// TimeWindow describes a recurring "quiet hours" window during which
+// only alerts at or above MinSeverityDuringQuiet are dispatched. Lower
+// severity events are still recorded to metrics/logs, just not pushed
+// to notification channels.
+type TimeWindow struct {
+	// Start and End are "HH:MM" in the local time of the monitoring
+	// process. End may be earlier than Start to express a window that
+	// wraps past midnight (e.g. Start: "22:00", End: "06:00").
+	Start string
+	End   string
+
+	// Weekdays restricts the window to specific days; empty means every
+	// day.
+	Weekdays []time.Weekday
+
+	MinSeverityDuringQuiet Severity
+}
+
+// contains reports whether now falls inside the window.
+func (w TimeWindow) contains(now time.Time) bool {
+	start, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", w.End)
+	if err != nil {
+		return false
+	}
+
+	nowOfDay := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+	startOfDay := time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute
+	endOfDay := time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute
+
+	if startOfDay <= endOfDay {
+		return w.weekdayAllowed(now.Weekday()) && nowOfDay >= startOfDay && nowOfDay < endOfDay
+	}
+
+	// Window wraps past midnight: the part before midnight belongs to
+	// today's weekday, but the continuation after midnight belongs to
+	// the weekday the window started on, i.e. yesterday.
+	if nowOfDay >= startOfDay {
+		return w.weekdayAllowed(now.Weekday())
+	}
+	if nowOfDay < endOfDay {
+		return w.weekdayAllowed(previousWeekday(now.Weekday()))
+	}
+	return false
+}
+
+// weekdayAllowed reports whether day is one of w.Weekdays, or w.Weekdays
+// is empty (meaning every day).
+func (w TimeWindow) weekdayAllowed(day time.Weekday) bool {
+	return len(w.Weekdays) == 0 || weekdayIn(w.Weekdays, day)
+}
+
+func weekdayIn(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func previousWeekday(day time.Weekday) time.Weekday {
+	return time.Weekday((int(day) + 6) % 7)
+}
+
+// quietSeverityFloor returns the highest MinSeverityDuringQuiet among
+// the configured windows that contain now, and whether any did.
+func quietSeverityFloor(windows []TimeWindow, now time.Time) (Severity, bool) {
+	floor := SeverityInfo
+	matched := false
+
+	for _, w := range windows {
+		if !w.contains(now) {
+			continue
+		}
+		matched = true
+		if w.MinSeverityDuringQuiet.rank() > floor.rank() {
+			floor = w.MinSeverityDuringQuiet
+		}
+	}
+
+	return floor, matched
+}
+
+// probeGuard prevents overlapping probes of the same app: if a
+// scheduled tick fires while the previous probe for that app is still
+// in flight, the tick is skipped rather than queued.
+type probeGuard struct {
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+func newProbeGuard() *probeGuard {
+	return &probeGuard{running: make(map[string]bool)}
+}
+
+// tryStart reports whether appUrl was idle and, if so, marks it
+// running. The caller must call finish(appUrl) once the probe
+// completes.
+func (g *probeGuard) tryStart(appUrl string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.running[appUrl] {
+		return false
+	}
+	g.running[appUrl] = true
+	return true
+}
+
+func (g *probeGuard) finish(appUrl string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.running, appUrl)
+}
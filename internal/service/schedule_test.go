@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeWindowContains(t *testing.T) {
+	mustTime := func(weekday string, hour, minute int) time.Time {
+		days := map[string]int{"Mon": 1, "Tue": 2, "Wed": 3, "Thu": 4, "Fri": 5, "Sat": 6, "Sun": 7}
+		return time.Date(2024, time.January, days[weekday], hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		w    TimeWindow
+		now  time.Time
+		want bool
+	}{
+		{
+			name: "same-day window inside range",
+			w:    TimeWindow{Start: "09:00", End: "17:00"},
+			now:  mustTime("Mon", 12, 0),
+			want: true,
+		},
+		{
+			name: "same-day window before start",
+			w:    TimeWindow{Start: "09:00", End: "17:00"},
+			now:  mustTime("Mon", 8, 59),
+			want: false,
+		},
+		{
+			name: "same-day window at end is exclusive",
+			w:    TimeWindow{Start: "09:00", End: "17:00"},
+			now:  mustTime("Mon", 17, 0),
+			want: false,
+		},
+		{
+			name: "overnight window before midnight",
+			w:    TimeWindow{Start: "22:00", End: "06:00"},
+			now:  mustTime("Mon", 23, 30),
+			want: true,
+		},
+		{
+			name: "overnight window after midnight",
+			w:    TimeWindow{Start: "22:00", End: "06:00"},
+			now:  mustTime("Tue", 2, 0),
+			want: true,
+		},
+		{
+			name: "overnight window outside range",
+			w:    TimeWindow{Start: "22:00", End: "06:00"},
+			now:  mustTime("Mon", 12, 0),
+			want: false,
+		},
+		{
+			name: "weekday-restricted overnight window before midnight on matching day",
+			w:    TimeWindow{Start: "22:00", End: "06:00", Weekdays: []time.Weekday{time.Monday}},
+			now:  mustTime("Mon", 23, 0),
+			want: true,
+		},
+		{
+			name: "weekday-restricted overnight window continues into next day",
+			w:    TimeWindow{Start: "22:00", End: "06:00", Weekdays: []time.Weekday{time.Monday}},
+			now:  mustTime("Tue", 3, 0),
+			want: true,
+		},
+		{
+			name: "weekday-restricted overnight window does not leak into the day after",
+			w:    TimeWindow{Start: "22:00", End: "06:00", Weekdays: []time.Weekday{time.Monday}},
+			now:  mustTime("Wed", 3, 0),
+			want: false,
+		},
+		{
+			name: "weekday-restricted overnight window does not fire on a non-listed start day",
+			w:    TimeWindow{Start: "22:00", End: "06:00", Weekdays: []time.Weekday{time.Monday}},
+			now:  mustTime("Tue", 23, 0),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.w.contains(tt.now); got != tt.want {
+				t.Errorf("contains(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
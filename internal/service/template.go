@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// TemplateContext is the data made available to MessageTemplates and
+// SubjectTemplate when rendering an event.
+type TemplateContext struct {
+	AppUrl      string
+	Timestamp   time.Time
+	Status      string
+	CpuUsage    float64
+	CpuCount    float64
+	MemoryUsed  float64
+	MemoryTotal float64
+	Threshold   float64
+	Severity    Severity
+	Error       string
+}
+
+// defaultSubjectTemplate preserves the subject line used before template
+// support was added.
+const defaultSubjectTemplate = "Spring Boot App Monitor - {{.AppUrl}}"
+
+// defaultMessageTemplates preserves the exact wording previously built
+// with fmt.Sprintf in monitorHealthAndMetrics, so existing setups render
+// identically unless they opt into a custom template.
+var defaultMessageTemplates = map[EventType]string{
+	EventTypeNoActuatorSupport: `[{{.Timestamp.Format "15:04"}}] No actuator support for: {{.AppUrl}}/actuator`,
+	EventTypeNotResponding:     `[{{.Timestamp.Format "15:04"}}] No response from app. Attention required!`,
+	EventTypeHealthStatus:      `[{{.Timestamp.Format "15:04"}}] Health Status: {{.Status}}. Attention required!`,
+	EventTypeHealthStatusError: `[{{.Timestamp.Format "15:04"}}] Failed to get health status: {{.Error}}`,
+	EventTypeMetricsError:      `[{{.Timestamp.Format "15:04"}}] Health status: {{.Status}}. Failed to get metrics: {{.Error}}`,
+	EventTypeThresholdExceeded: `[{{.Timestamp.Format "15:04"}}] Attention required! CPU: {{printf "%.2f" .CpuUsage}}%, JVM: {{printf "%.1f" .MemoryUsed}}/{{printf "%.1f" .MemoryTotal}} GB`,
+	EventTypeHealthy:           `[{{.Timestamp.Format "15:04"}}] CPU: {{printf "%.2f" .CpuUsage}}%, JVM: {{printf "%.1f" .MemoryUsed}}/{{printf "%.1f" .MemoryTotal}} GB`,
+	EventTypeResolved:          `[{{.Timestamp.Format "15:04"}}] RESOLVED: {{.AppUrl}}{{if .Status}} no longer {{.Status}}{{else}} is healthy again{{end}}`,
+}
+
+// messageRenderer precompiles the subject and per-EventType message
+// templates once at startup so handleAlert only has to execute them.
+type messageRenderer struct {
+	subject  *template.Template
+	messages map[EventType]*template.Template
+}
+
+// newMessageRenderer merges userTemplates over the defaults (a missing
+// EventType falls back to its built-in wording) and parses everything
+// up front, so a typo in a template is caught at startup, not the first
+// time that event fires.
+func newMessageRenderer(subjectTemplate string, userTemplates map[EventType]string) (*messageRenderer, error) {
+	if subjectTemplate == "" {
+		subjectTemplate = defaultSubjectTemplate
+	}
+
+	subject, err := template.New("subject").Parse(subjectTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subject template: %w", err)
+	}
+
+	merged := make(map[EventType]string, len(defaultMessageTemplates))
+	for eventType, tmpl := range defaultMessageTemplates {
+		merged[eventType] = tmpl
+	}
+	for eventType, tmpl := range userTemplates {
+		merged[eventType] = tmpl
+	}
+
+	messages := make(map[EventType]*template.Template, len(merged))
+	for eventType, tmpl := range merged {
+		parsed, err := template.New(string(eventType)).Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message template for %q: %w", eventType, err)
+		}
+		messages[eventType] = parsed
+	}
+
+	return &messageRenderer{subject: subject, messages: messages}, nil
+}
+
+// renderSubject renders the subject template for ctx.
+func (r *messageRenderer) renderSubject(ctx TemplateContext) (string, error) {
+	var buf bytes.Buffer
+	if err := r.subject.Execute(&buf, ctx); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderMessage renders the message template registered for eventType.
+func (r *messageRenderer) renderMessage(eventType EventType, ctx TemplateContext) (string, error) {
+	tmpl, ok := r.messages[eventType]
+	if !ok {
+		return "", fmt.Errorf("no message template registered for event type %q", eventType)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ctx); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}